@@ -0,0 +1,207 @@
+//go:build linux
+// +build linux
+
+package term
+
+import (
+	"fmt"
+
+	"github.com/pkg/term/termios"
+	"golang.org/x/sys/unix"
+)
+
+// cmspar selects "stick" (mark/space) parity together with PARENB. It is a
+// Linux extension (linux/termbits.h) with no portable syscall constant, so
+// it's defined locally like the other ioctl/cflag bits this package adds.
+const cmspar = 0x40000000
+
+// Parity selects the parity scheme used by SetMode/GetMode.
+type Parity int
+
+const (
+	ParityNone Parity = iota
+	ParityOdd
+	ParityEven
+	ParityMark
+	ParitySpace
+)
+
+// StopBits selects the number of stop bits used by SetMode/GetMode.
+type StopBits int
+
+const (
+	Stop1 StopBits = iota
+	Stop1Point5
+	Stop2
+)
+
+// FlowControl selects the flow control scheme used by SetMode/GetMode.
+type FlowControl int
+
+const (
+	FlowNone FlowControl = iota
+	FlowHardware
+	FlowSoftware
+)
+
+// Mode is a high-level description of a port's line configuration, used by
+// SetMode and GetMode as an alternative to poking at termios cflag/iflag
+// bits directly.
+type Mode struct {
+	BaudRate    int
+	DataBits    int
+	Parity      Parity
+	StopBits    StopBits
+	FlowControl FlowControl
+}
+
+// SetMode configures the port's baud rate, data bits, parity, stop bits,
+// and flow control. Data bits, parity, stop bits, and flow control are
+// applied in a single Tcsetattr call; a nonzero BaudRate is then set with
+// a separate call, the same way SetSpeedCustom does. A zero BaudRate
+// leaves the current speed unchanged.
+func (t *Term) SetMode(m Mode) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var a unix.Termios
+	if err := termios.Tcgetattr(uintptr(t.fd), &a); err != nil {
+		return err
+	}
+	if err := applyMode(&a, m); err != nil {
+		return err
+	}
+	if err := termios.Tcsetattr(uintptr(t.fd), termios.TCSANOW, &a); err != nil {
+		return err
+	}
+	if m.BaudRate != 0 {
+		return t.setSpeedCustom(m.BaudRate)
+	}
+	return nil
+}
+
+// GetMode returns the port's current baud rate, data bits, parity, stop
+// bits, and flow control.
+func (t *Term) GetMode() (Mode, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var a unix.Termios
+	if err := termios.Tcgetattr(uintptr(t.fd), &a); err != nil {
+		return Mode{}, err
+	}
+	m := modeFromCflag(a.Cflag, a.Iflag)
+
+	baud, err := t.getSpeed()
+	if err != nil {
+		return Mode{}, err
+	}
+	m.BaudRate = baud
+
+	return m, nil
+}
+
+// applyMode translates m's data bits, parity, stop bits, and flow control
+// into the corresponding cflag/iflag bits of a.
+func applyMode(a *unix.Termios, m Mode) error {
+	a.Cflag &^= unix.CSIZE
+	switch m.DataBits {
+	case 5:
+		a.Cflag |= unix.CS5
+	case 6:
+		a.Cflag |= unix.CS6
+	case 7:
+		a.Cflag |= unix.CS7
+	case 8:
+		a.Cflag |= unix.CS8
+	default:
+		return fmt.Errorf("term: unsupported data bits %d", m.DataBits)
+	}
+
+	a.Cflag &^= unix.PARENB | unix.PARODD | cmspar
+	switch m.Parity {
+	case ParityNone:
+	case ParityOdd:
+		a.Cflag |= unix.PARENB | unix.PARODD
+	case ParityEven:
+		a.Cflag |= unix.PARENB
+	case ParityMark:
+		a.Cflag |= unix.PARENB | unix.PARODD | cmspar
+	case ParitySpace:
+		a.Cflag |= unix.PARENB | cmspar
+	default:
+		return fmt.Errorf("term: unsupported parity %d", m.Parity)
+	}
+
+	a.Cflag &^= unix.CSTOPB
+	switch m.StopBits {
+	case Stop1, Stop1Point5:
+		// CSTOPB selects two stop bits; 1.5 stop bits has no POSIX
+		// termios representation, so it's treated as 1.
+	case Stop2:
+		a.Cflag |= unix.CSTOPB
+	default:
+		return fmt.Errorf("term: unsupported stop bits %d", m.StopBits)
+	}
+
+	a.Cflag &^= unix.CRTSCTS
+	a.Iflag &^= unix.IXON | unix.IXOFF
+	switch m.FlowControl {
+	case FlowNone:
+	case FlowHardware:
+		a.Cflag |= unix.CRTSCTS
+	case FlowSoftware:
+		a.Iflag |= unix.IXON | unix.IXOFF
+	default:
+		return fmt.Errorf("term: unsupported flow control %d", m.FlowControl)
+	}
+
+	return nil
+}
+
+// modeFromCflag translates the cflag/iflag bits of a termios into a Mode's
+// data bits, parity, stop bits, and flow control. BaudRate is left zero;
+// callers fill it in separately since it isn't read from these fields.
+func modeFromCflag(cflag, iflag uint32) Mode {
+	var m Mode
+	switch cflag & unix.CSIZE {
+	case unix.CS5:
+		m.DataBits = 5
+	case unix.CS6:
+		m.DataBits = 6
+	case unix.CS7:
+		m.DataBits = 7
+	case unix.CS8:
+		m.DataBits = 8
+	}
+
+	switch {
+	case cflag&unix.PARENB == 0:
+		m.Parity = ParityNone
+	case cflag&cmspar != 0 && cflag&unix.PARODD != 0:
+		m.Parity = ParityMark
+	case cflag&cmspar != 0:
+		m.Parity = ParitySpace
+	case cflag&unix.PARODD != 0:
+		m.Parity = ParityOdd
+	default:
+		m.Parity = ParityEven
+	}
+
+	if cflag&unix.CSTOPB != 0 {
+		m.StopBits = Stop2
+	} else {
+		m.StopBits = Stop1
+	}
+
+	switch {
+	case cflag&unix.CRTSCTS != 0:
+		m.FlowControl = FlowHardware
+	case iflag&(unix.IXON|unix.IXOFF) != 0:
+		m.FlowControl = FlowSoftware
+	default:
+		m.FlowControl = FlowNone
+	}
+
+	return m
+}
@@ -0,0 +1,54 @@
+//go:build linux
+// +build linux
+
+package term
+
+import (
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestApplyModeAndModeFromCflagRoundTrip(t *testing.T) {
+	cases := []Mode{
+		{DataBits: 8, Parity: ParityNone, StopBits: Stop1, FlowControl: FlowNone},
+		{DataBits: 7, Parity: ParityOdd, StopBits: Stop1, FlowControl: FlowNone},
+		{DataBits: 7, Parity: ParityEven, StopBits: Stop2, FlowControl: FlowNone},
+		{DataBits: 8, Parity: ParityMark, StopBits: Stop1, FlowControl: FlowHardware},
+		{DataBits: 8, Parity: ParitySpace, StopBits: Stop1, FlowControl: FlowSoftware},
+		{DataBits: 5, Parity: ParityNone, StopBits: Stop1Point5, FlowControl: FlowNone},
+		{DataBits: 6, Parity: ParityNone, StopBits: Stop1, FlowControl: FlowNone},
+	}
+
+	for _, m := range cases {
+		var a unix.Termios
+		if err := applyMode(&a, m); err != nil {
+			t.Fatalf("applyMode(%+v): %v", m, err)
+		}
+		got := modeFromCflag(a.Cflag, a.Iflag)
+		want := m
+		if want.StopBits == Stop1Point5 {
+			// 1.5 stop bits has no termios representation; it round-trips as 1.
+			want.StopBits = Stop1
+		}
+		want.BaudRate = 0
+		if got != want {
+			t.Errorf("applyMode(%+v) -> modeFromCflag = %+v, want %+v", m, got, want)
+		}
+	}
+}
+
+func TestApplyModeRejectsUnsupportedFields(t *testing.T) {
+	var a unix.Termios
+	cases := []Mode{
+		{DataBits: 9},
+		{DataBits: 8, Parity: Parity(99)},
+		{DataBits: 8, StopBits: StopBits(99)},
+		{DataBits: 8, FlowControl: FlowControl(99)},
+	}
+	for _, m := range cases {
+		if err := applyMode(&a, m); err == nil {
+			t.Errorf("applyMode(%+v): expected error, got nil", m)
+		}
+	}
+}
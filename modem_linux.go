@@ -0,0 +1,20 @@
+//go:build linux
+// +build linux
+
+package term
+
+import "golang.org/x/sys/unix"
+
+const tiocmiwait = 0x545C
+
+// WaitForChange blocks until one of the MODEM signals in mask (a bitwise
+// OR of the syscall.TIOCM_* constants) changes, then returns the current
+// status.
+func (t *Term) WaitForChange(mask uint) (Status, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if err := unix.IoctlSetInt(t.fd, tiocmiwait, int(mask)); err != nil {
+		return 0, err
+	}
+	return t.status()
+}
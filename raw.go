@@ -0,0 +1,73 @@
+package term
+
+import (
+	"syscall"
+
+	"github.com/pkg/term/termios"
+	"golang.org/x/sys/unix"
+)
+
+// RawMode is an Open option that puts the terminal into raw mode: input is
+// available character by character, echoing is disabled, and all special
+// processing of terminal input and output characters is disabled.
+func RawMode(t *Term) error {
+	return t.SetRaw()
+}
+
+// CBreakMode is an Open option that puts the terminal into cbreak mode:
+// input is available character by character, echoing is disabled, but
+// signal characters (INTR, QUIT, etc.) are still processed.
+func CBreakMode(t *Term) error {
+	return t.SetCbreak()
+}
+
+// Speed returns an Open option that sets the receive and transmit baud
+// rate to baud, including non-standard rates where the platform supports
+// it (see SetSpeedCustom).
+func Speed(baud int) func(*Term) error {
+	return func(t *Term) error {
+		return t.SetSpeedCustom(baud)
+	}
+}
+
+// SetRaw puts the terminal into raw mode.
+func (t *Term) SetRaw() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var a unix.Termios
+	if err := termios.Tcgetattr(uintptr(t.fd), &a); err != nil {
+		return err
+	}
+	a.Lflag &^= syscall.ICANON | syscall.ECHO | syscall.ISIG
+	a.Iflag &^= syscall.ISTRIP | syscall.INLCR | syscall.ICRNL | syscall.IGNCR | syscall.IXON
+	a.Oflag &^= syscall.OPOST
+	a.Cc[syscall.VMIN] = 1
+	a.Cc[syscall.VTIME] = 0
+	return termios.Tcsetattr(uintptr(t.fd), termios.TCSANOW, &a)
+}
+
+// SetCbreak puts the terminal into cbreak mode: input is available
+// character by character and echoing is disabled, but signal characters
+// (INTR, QUIT, etc.) are left enabled. Unlike SetRaw, it leaves the input
+// flags and output processing (OPOST) unchanged.
+func (t *Term) SetCbreak() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var a unix.Termios
+	if err := termios.Tcgetattr(uintptr(t.fd), &a); err != nil {
+		return err
+	}
+	a.Lflag &^= syscall.ICANON | syscall.ECHO
+	a.Lflag |= syscall.ISIG
+	a.Cc[syscall.VMIN] = 1
+	a.Cc[syscall.VTIME] = 0
+	return termios.Tcsetattr(uintptr(t.fd), termios.TCSANOW, &a)
+}
+
+// Restore restores the terminal attributes to what they were when the port
+// was opened.
+func (t *Term) Restore() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return termios.Tcsetattr(uintptr(t.fd), termios.TCSANOW, &t.original)
+}
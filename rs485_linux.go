@@ -0,0 +1,91 @@
+//go:build linux
+// +build linux
+
+package term
+
+import (
+	"time"
+	"unsafe"
+)
+
+const (
+	tiocsrs485 = 0x542F
+	tiocgrs485 = 0x542E
+
+	serialRS485Enabled      = 1 << 0
+	serialRS485RTSOnSend    = 1 << 1
+	serialRS485RTSAfterSend = 1 << 2
+	serialRS485RxDuringTx   = 1 << 4
+)
+
+// serialRS485 mirrors the Linux struct serial_rs485 (linux/serial.h).
+type serialRS485 struct {
+	Flags              uint32
+	DelayRTSBeforeSend uint32
+	DelayRTSAfterSend  uint32
+	Padding            [5]uint32
+}
+
+// RS485Config describes the RS-485 half-duplex settings for a port, as
+// programmed via TIOCSRS485/TIOCGRS485.
+type RS485Config struct {
+	Enabled            bool
+	RTSOnSend          bool
+	RTSAfterSend       bool
+	RxDuringTx         bool
+	DelayRTSBeforeSend time.Duration
+	DelayRTSAfterSend  time.Duration
+}
+
+// SetRS485 configures the port for RS-485 half-duplex operation, driving
+// RTS to control an external transceiver's direction.
+func (t *Term) SetRS485(cfg RS485Config) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	s := rs485FromConfig(cfg)
+	return ioctlPtr(t.fd, tiocsrs485, unsafe.Pointer(&s))
+}
+
+// GetRS485 returns the port's current RS-485 half-duplex configuration.
+func (t *Term) GetRS485() (RS485Config, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var s serialRS485
+	if err := ioctlPtr(t.fd, tiocgrs485, unsafe.Pointer(&s)); err != nil {
+		return RS485Config{}, err
+	}
+	return configFromRS485(s), nil
+}
+
+// rs485FromConfig packs cfg's fields into the serial_rs485 flag/delay
+// layout the kernel expects.
+func rs485FromConfig(cfg RS485Config) serialRS485 {
+	var s serialRS485
+	if cfg.Enabled {
+		s.Flags |= serialRS485Enabled
+	}
+	if cfg.RTSOnSend {
+		s.Flags |= serialRS485RTSOnSend
+	}
+	if cfg.RTSAfterSend {
+		s.Flags |= serialRS485RTSAfterSend
+	}
+	if cfg.RxDuringTx {
+		s.Flags |= serialRS485RxDuringTx
+	}
+	s.DelayRTSBeforeSend = uint32(cfg.DelayRTSBeforeSend / time.Millisecond)
+	s.DelayRTSAfterSend = uint32(cfg.DelayRTSAfterSend / time.Millisecond)
+	return s
+}
+
+// configFromRS485 unpacks a serial_rs485 value into an RS485Config.
+func configFromRS485(s serialRS485) RS485Config {
+	return RS485Config{
+		Enabled:            s.Flags&serialRS485Enabled != 0,
+		RTSOnSend:          s.Flags&serialRS485RTSOnSend != 0,
+		RTSAfterSend:       s.Flags&serialRS485RTSAfterSend != 0,
+		RxDuringTx:         s.Flags&serialRS485RxDuringTx != 0,
+		DelayRTSBeforeSend: time.Duration(s.DelayRTSBeforeSend) * time.Millisecond,
+		DelayRTSAfterSend:  time.Duration(s.DelayRTSAfterSend) * time.Millisecond,
+	}
+}
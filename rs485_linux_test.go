@@ -0,0 +1,46 @@
+//go:build linux
+// +build linux
+
+package term
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRS485ConfigRoundTrip(t *testing.T) {
+	cases := []RS485Config{
+		{},
+		{Enabled: true},
+		{Enabled: true, RTSOnSend: true},
+		{Enabled: true, RTSAfterSend: true, RxDuringTx: true},
+		{
+			Enabled:            true,
+			RTSOnSend:          true,
+			RTSAfterSend:       true,
+			RxDuringTx:         true,
+			DelayRTSBeforeSend: 50 * time.Millisecond,
+			DelayRTSAfterSend:  100 * time.Millisecond,
+		},
+	}
+
+	for _, cfg := range cases {
+		got := configFromRS485(rs485FromConfig(cfg))
+		if got != cfg {
+			t.Errorf("rs485FromConfig(%+v) round trip = %+v, want %+v", cfg, got, cfg)
+		}
+	}
+}
+
+func TestRS485FromConfigFlags(t *testing.T) {
+	s := rs485FromConfig(RS485Config{Enabled: true, RTSOnSend: true})
+	if s.Flags&serialRS485Enabled == 0 {
+		t.Error("Flags missing serialRS485Enabled")
+	}
+	if s.Flags&serialRS485RTSOnSend == 0 {
+		t.Error("Flags missing serialRS485RTSOnSend")
+	}
+	if s.Flags&serialRS485RTSAfterSend != 0 {
+		t.Error("Flags has unexpected serialRS485RTSAfterSend")
+	}
+}
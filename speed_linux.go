@@ -0,0 +1,141 @@
+//go:build linux
+// +build linux
+
+package term
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/pkg/term/termios"
+	"golang.org/x/sys/unix"
+)
+
+// termios2 mirrors the Linux struct termios2 (asm-generic/termbits.h). It
+// extends the classic termios with explicit c_ispeed/c_ospeed fields so
+// TCSETS2/TCGETS2 can program arbitrary baud rates via BOTHER.
+type termios2 struct {
+	Iflag  uint32
+	Oflag  uint32
+	Cflag  uint32
+	Lflag  uint32
+	Line   uint8
+	Cc     [19]uint8
+	Ispeed uint32
+	Ospeed uint32
+}
+
+const (
+	tcgets2 = 0x802c542a
+	tcsets2 = 0x402c542b
+
+	cbaud   = 0010017
+	cbaudex = 0010000
+	bother  = 0010000
+)
+
+// ioctlPtr issues an ioctl that takes a pointer argument. x/sys/unix has
+// no generic "set pointer" helper for custom structs it doesn't already
+// know about (only typed ones like IoctlSetTermios), so termios2 and
+// serial_rs485 go through the raw ioctl syscall directly.
+func ioctlPtr(fd int, req uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), req, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// standardBauds maps the classic POSIX baud rates, plus the high-speed
+// rates Linux adds on top of them, to their Bxxx termios constant.
+var standardBauds = map[int]uint32{
+	50: unix.B50, 75: unix.B75, 110: unix.B110, 134: unix.B134, 150: unix.B150,
+	200: unix.B200, 300: unix.B300, 600: unix.B600, 1200: unix.B1200, 1800: unix.B1800,
+	2400: unix.B2400, 4800: unix.B4800, 9600: unix.B9600, 19200: unix.B19200,
+	38400: unix.B38400, 57600: unix.B57600, 115200: unix.B115200, 230400: unix.B230400,
+	460800: unix.B460800, 500000: unix.B500000, 576000: unix.B576000, 921600: unix.B921600,
+	1000000: unix.B1000000, 1152000: unix.B1152000, 1500000: unix.B1500000,
+	2000000: unix.B2000000, 2500000: unix.B2500000, 3000000: unix.B3000000,
+	3500000: unix.B3500000, 4000000: unix.B4000000,
+}
+
+// SetSpeed sets the receive and transmit baud rates.
+func (t *Term) SetSpeed(baud int) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.setSpeed(baud)
+}
+
+// setSpeed is SetSpeed without locking t.mu, for callers that already hold
+// it.
+func (t *Term) setSpeed(baud int) error {
+	rate, ok := standardBauds[baud]
+	if !ok {
+		return fmt.Errorf("term: unsupported baud rate %d", baud)
+	}
+	var a unix.Termios
+	if err := termios.Tcgetattr(uintptr(t.fd), &a); err != nil {
+		return err
+	}
+	a.Cflag = a.Cflag&^uint32(cbaud|cbaudex) | rate
+	a.Ispeed = rate
+	a.Ospeed = rate
+	return termios.Tcsetattr(uintptr(t.fd), termios.TCSANOW, &a)
+}
+
+// standardSpeeds lists the baud rates that map onto a classic Bxxx
+// constant; anything else requires BOTHER. Derived from standardBauds so
+// the two never drift apart.
+var standardSpeeds = func() map[int]bool {
+	m := make(map[int]bool, len(standardBauds))
+	for baud := range standardBauds {
+		m[baud] = true
+	}
+	return m
+}()
+
+// SetSpeedCustom sets the receive and transmit baud rate to an arbitrary
+// value, not just one of the classic Bxxx constants. Standard rates are
+// programmed through the usual Tcsetattr path; non-standard rates (e.g.
+// 250000 for DMX, 31250 for MIDI) are programmed with the termios2
+// TCSETS2 ioctl and the BOTHER cflag, falling back to SetSpeed if the
+// kernel rejects BOTHER.
+func (t *Term) SetSpeedCustom(baud int) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.setSpeedCustom(baud)
+}
+
+// setSpeedCustom is SetSpeedCustom without locking t.mu, for callers
+// (SetMode) that already hold it.
+func (t *Term) setSpeedCustom(baud int) error {
+	if standardSpeeds[baud] {
+		return t.setSpeed(baud)
+	}
+
+	var tio termios2
+	if err := ioctlPtr(t.fd, tcgets2, unsafe.Pointer(&tio)); err != nil {
+		return err
+	}
+
+	tio.Cflag &^= cbaud | cbaudex
+	tio.Cflag |= bother
+	tio.Ispeed = uint32(baud)
+	tio.Ospeed = uint32(baud)
+
+	if err := ioctlPtr(t.fd, tcsets2, unsafe.Pointer(&tio)); err != nil {
+		return t.setSpeed(baud)
+	}
+	return nil
+}
+
+// getSpeed returns the port's current baud rate by reading it back through
+// TCGETS2: the kernel fills in c_ispeed/c_ospeed regardless of whether the
+// rate was programmed via a classic Bxxx constant or BOTHER, so this is
+// accurate for both paths.
+func (t *Term) getSpeed() (int, error) {
+	var tio termios2
+	if err := ioctlPtr(t.fd, tcgets2, unsafe.Pointer(&tio)); err != nil {
+		return 0, err
+	}
+	return int(tio.Ispeed), nil
+}
@@ -0,0 +1,19 @@
+//go:build linux
+// +build linux
+
+package term
+
+import "testing"
+
+func TestStandardSpeedsClassification(t *testing.T) {
+	for _, baud := range []int{50, 9600, 115200, 230400, 921600, 4000000} {
+		if !standardSpeeds[baud] {
+			t.Errorf("standardSpeeds[%d] = false, want true", baud)
+		}
+	}
+	for _, baud := range []int{250000, 31250, 123456} {
+		if standardSpeeds[baud] {
+			t.Errorf("standardSpeeds[%d] = true, want false (non-standard rate)", baud)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+//go:build !linux
+// +build !linux
+
+package term
+
+import (
+	"fmt"
+
+	"github.com/pkg/term/termios"
+	"golang.org/x/sys/unix"
+)
+
+// standardBauds maps the classic POSIX baud rates to their Bxxx termios
+// constant. Linux defines additional high-speed rates on top of these (see
+// speed_linux.go); this is the portable subset common to every unix termios
+// implementation.
+var standardBauds = map[int]uint64{
+	50: unix.B50, 75: unix.B75, 110: unix.B110, 134: unix.B134, 150: unix.B150,
+	200: unix.B200, 300: unix.B300, 600: unix.B600, 1200: unix.B1200, 1800: unix.B1800,
+	2400: unix.B2400, 4800: unix.B4800, 9600: unix.B9600, 19200: unix.B19200,
+	38400: unix.B38400, 57600: unix.B57600, 115200: unix.B115200,
+}
+
+// SetSpeed sets the receive and transmit baud rates.
+func (t *Term) SetSpeed(baud int) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.setSpeed(baud)
+}
+
+// setSpeed is SetSpeed without locking t.mu, for callers that already hold
+// it.
+func (t *Term) setSpeed(baud int) error {
+	rate, ok := standardBauds[baud]
+	if !ok {
+		return fmt.Errorf("term: unsupported baud rate %d", baud)
+	}
+	var a unix.Termios
+	if err := termios.Tcgetattr(uintptr(t.fd), &a); err != nil {
+		return err
+	}
+	a.Ispeed = rate
+	a.Ospeed = rate
+	return termios.Tcsetattr(uintptr(t.fd), termios.TCSANOW, &a)
+}
+
+// SetSpeedCustom sets the receive and transmit baud rate. Platforms other
+// than Linux have no BOTHER-style escape hatch for arbitrary rates, so this
+// is equivalent to SetSpeed.
+func (t *Term) SetSpeedCustom(baud int) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.setSpeed(baud)
+}
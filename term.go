@@ -6,84 +6,194 @@ package term
 import (
 	"io"
 	"os"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/pkg/term/termios"
+	"golang.org/x/sys/unix"
 )
 
 // Term represents an asynchronous communications port.
 type Term struct {
-	name string
-	fd   int
+	name     string
+	fd       int
+	original unix.Termios
+
+	mu        sync.RWMutex
+	closeOnce sync.Once
+	pipeR     int
+	pipeW     int
+
+	timeoutMu   sync.Mutex
+	readTimeout time.Duration
+	deadline    time.Time
 }
 
-// Open opens an asynchronous communications port.
-func Open(name string) (*Term, error) {
+// Open opens an asynchronous communications port. The options, if any, are
+// applied in order once the port is open; if any option returns an error,
+// Open closes the port and returns that error.
+func Open(name string, options ...func(*Term) error) (*Term, error) {
 	fd, e := syscall.Open(name, syscall.O_NOCTTY|syscall.O_CLOEXEC|syscall.O_RDWR, 0666)
 	if e != nil {
 		return nil, &os.PathError{"open", name, e}
 	}
-	return &Term{name: name, fd: fd}, nil
+	if e := unix.SetNonblock(fd, true); e != nil {
+		syscall.Close(fd)
+		return nil, &os.PathError{"open", name, e}
+	}
+	pipe := make([]int, 2)
+	if e := unix.Pipe2(pipe, unix.O_CLOEXEC|unix.O_NONBLOCK); e != nil {
+		syscall.Close(fd)
+		return nil, &os.PathError{"open", name, e}
+	}
+	t := &Term{name: name, fd: fd, pipeR: pipe[0], pipeW: pipe[1]}
+	if err := termios.Tcgetattr(uintptr(t.fd), &t.original); err != nil {
+		syscall.Close(fd)
+		unix.Close(pipe[0])
+		unix.Close(pipe[1])
+		return nil, err
+	}
+	for _, option := range options {
+		if err := option(t); err != nil {
+			syscall.Close(fd)
+			unix.Close(pipe[0])
+			unix.Close(pipe[1])
+			return nil, err
+		}
+	}
+	return t, nil
 }
 
 // Read reads up to len(b) bytes from the terminal. It returns the number of
 // bytes read and an error, if any. EOF is signaled by a zero count with
-// err set to io.EOF.
+// err set to io.EOF. If a read timeout or deadline has been set and no
+// data arrives in time, Read returns a *PortError wrapping
+// os.ErrDeadlineExceeded. A concurrent Close unblocks an in-flight Read
+// immediately.
 func (t *Term) Read(b []byte) (int, error) {
-	n, e := syscall.Read(t.fd, b)
-	if n < 0 {
-		n = 0
-	}
-	if n == 0 && len(b) > 0 && e == nil {
-		return 0, io.EOF
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.fd == -1 {
+		return 0, &os.PathError{"read", t.name, syscall.EBADF}
 	}
-	if e != nil {
-		return n, &os.PathError{"read", t.name, e}
+
+	for {
+		n, e := syscall.Read(t.fd, b)
+		if e == syscall.EAGAIN || e == syscall.EWOULDBLOCK {
+			ready, err := t.waitReadable()
+			if err != nil {
+				return 0, err
+			}
+			if !ready {
+				return 0, &PortError{Op: "read", Err: os.ErrDeadlineExceeded}
+			}
+			continue
+		}
+		if n < 0 {
+			n = 0
+		}
+		if n == 0 && len(b) > 0 && e == nil {
+			return 0, io.EOF
+		}
+		if e != nil {
+			return n, &os.PathError{"read", t.name, e}
+		}
+		return n, nil
 	}
-	return n, nil
 }
 
-// Write writes len(b) bytes to the terminal. It returns the number of bytes
-// written and an error, if any. Write returns a non-nil error when n !=
-// len(b).
+// Write writes len(b) bytes to the terminal, blocking until all of it is
+// written or Close unblocks it. It returns the number of bytes written and
+// an error, if any.
 func (t *Term) Write(b []byte) (int, error) {
-	n, e := syscall.Write(t.fd, b)
-	if n < 0 {
-		n = 0
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.fd == -1 {
+		return 0, &os.PathError{"write", t.name, syscall.EBADF}
 	}
-	if n != len(b) {
-		return n, io.ErrShortWrite
+
+	var written int
+	for written < len(b) {
+		n, e := syscall.Write(t.fd, b[written:])
+		if n > 0 {
+			written += n
+		}
+		if e == syscall.EAGAIN || e == syscall.EWOULDBLOCK {
+			if err := t.waitWritable(); err != nil {
+				return written, err
+			}
+			continue
+		}
+		if e != nil {
+			return written, &os.PathError{"write", t.name, e}
+		}
 	}
-	if e != nil {
-		return n, &os.PathError{"write", t.name, e}
+	return written, nil
+}
+
+// waitWritable blocks until the port fd is writable or Close wakes the
+// self-pipe, the same way waitReadable does for Read.
+func (t *Term) waitWritable() error {
+	fds := []unix.PollFd{
+		{Fd: int32(t.fd), Events: unix.POLLOUT},
+		{Fd: int32(t.pipeR), Events: unix.POLLIN},
+	}
+	for {
+		n, err := unix.Poll(fds, -1)
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			return &PortError{Op: "write", Err: err}
+		}
+		if n == 0 {
+			continue
+		}
+		if fds[1].Revents&unix.POLLIN != 0 {
+			return &PortError{Op: "write", Err: errClosed}
+		}
+		if fds[0].Revents&unix.POLLOUT != 0 {
+			return nil
+		}
 	}
-	return n, nil
 }
 
-// Close closes the device and releases any associated resources.
+// Close closes the device and releases any associated resources. If a Read
+// is in flight on another goroutine, Close wakes it via a self-pipe and
+// waits for it to return before closing the fd, so the fd is never closed
+// out from under a concurrent read.
 func (t *Term) Close() error {
+	t.closeOnce.Do(func() {
+		unix.Write(t.pipeW, []byte{0})
+	})
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.fd == -1 {
+		return nil
+	}
 	err := syscall.Close(t.fd)
 	t.fd = -1
+	unix.Close(t.pipeR)
+	unix.Close(t.pipeW)
 	return err
 }
 
-// SetSpeed sets the receive and transmit baud rates.
-func (t *Term) SetSpeed(baud int) error {
-	var a attr
-	if err := termios.Tcgetattr(uintptr(t.fd), (*syscall.Termios)(&a)); err != nil {
-		return err
-	}
-	a.setSpeed(baud)
-	return termios.Tcsetattr(uintptr(t.fd), termios.TCSANOW, (*syscall.Termios)(&a))
-}
-
 // Flush flushes both data received but not read, and data written but not transmitted.
 func (t *Term) Flush() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return termios.Tcflush(uintptr(t.fd), termios.TCIOFLUSH)
 }
 
 // SendBreak sends a break signal.
 func (t *Term) SendBreak() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return termios.Tcsendbreak(uintptr(t.fd), 0)
 }
 
@@ -102,10 +212,41 @@ func (s *Status) SetDTR(v bool) {
 // DTR returns the state of the DTR (data terminal ready) signal.
 func (s *Status) DTR() bool { return (*s)&syscall.TIOCM_DTR == syscall.TIOCM_DTR }
 
+// SetRTS sets the RTS (request to send) signal.
+func (s *Status) SetRTS(v bool) {
+	if v {
+		(*s) |= syscall.TIOCM_RTS
+	} else {
+		(*s) &= ^syscall.TIOCM_RTS
+	}
+}
+
+// RTS returns the state of the RTS (request to send) signal.
+func (s *Status) RTS() bool { return (*s)&syscall.TIOCM_RTS == syscall.TIOCM_RTS }
+
+// CTS returns the state of the CTS (clear to send) signal.
+func (s *Status) CTS() bool { return (*s)&syscall.TIOCM_CTS == syscall.TIOCM_CTS }
+
+// DSR returns the state of the DSR (data set ready) signal.
+func (s *Status) DSR() bool { return (*s)&syscall.TIOCM_DSR == syscall.TIOCM_DSR }
+
+// RI returns the state of the RI (ring indicator) signal.
+func (s *Status) RI() bool { return (*s)&syscall.TIOCM_RNG == syscall.TIOCM_RNG }
+
+// DCD returns the state of the DCD (data carrier detect) signal.
+func (s *Status) DCD() bool { return (*s)&syscall.TIOCM_CAR == syscall.TIOCM_CAR }
+
 // Status returns the state of the "MODEM" bits.
 func (t *Term) Status() (Status, error) {
-	var status int
-	if err := termios.Tiocmget(uintptr(t.fd), &status); err != nil {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.status()
+}
+
+// status is Status without locking t.mu, for callers that already hold it.
+func (t *Term) status() (Status, error) {
+	status, err := termios.Tiocmget(uintptr(t.fd))
+	if err != nil {
 		return 0, err
 	}
 	return Status(status), nil
@@ -113,5 +254,88 @@ func (t *Term) Status() (Status, error) {
 
 // SetStatus sets the state of the "MODEM" bits.
 func (t *Term) SetStatus(status Status) error {
-	return termios.Tiocmset(uintptr(t.fd), (*int)(&status))
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.setStatus(status)
+}
+
+// setStatus is SetStatus without locking t.mu, for callers that already
+// hold it.
+func (t *Term) setStatus(status Status) error {
+	return termios.Tiocmset(uintptr(t.fd), int(status))
+}
+
+// DTR returns the state of the DTR (data terminal ready) signal.
+func (t *Term) DTR() (bool, error) {
+	status, err := t.Status()
+	if err != nil {
+		return false, err
+	}
+	return status.DTR(), nil
+}
+
+// SetDTR sets the state of the DTR (data terminal ready) signal.
+func (t *Term) SetDTR(v bool) error {
+	return t.setStatusBit(func(s *Status) { s.SetDTR(v) })
+}
+
+// RTS returns the state of the RTS (request to send) signal.
+func (t *Term) RTS() (bool, error) {
+	status, err := t.Status()
+	if err != nil {
+		return false, err
+	}
+	return status.RTS(), nil
+}
+
+// SetRTS sets the state of the RTS (request to send) signal.
+func (t *Term) SetRTS(v bool) error {
+	return t.setStatusBit(func(s *Status) { s.SetRTS(v) })
+}
+
+// CTS returns the state of the CTS (clear to send) signal.
+func (t *Term) CTS() (bool, error) {
+	status, err := t.Status()
+	if err != nil {
+		return false, err
+	}
+	return status.CTS(), nil
+}
+
+// DSR returns the state of the DSR (data set ready) signal.
+func (t *Term) DSR() (bool, error) {
+	status, err := t.Status()
+	if err != nil {
+		return false, err
+	}
+	return status.DSR(), nil
+}
+
+// RI returns the state of the RI (ring indicator) signal.
+func (t *Term) RI() (bool, error) {
+	status, err := t.Status()
+	if err != nil {
+		return false, err
+	}
+	return status.RI(), nil
+}
+
+// DCD returns the state of the DCD (data carrier detect) signal.
+func (t *Term) DCD() (bool, error) {
+	status, err := t.Status()
+	if err != nil {
+		return false, err
+	}
+	return status.DCD(), nil
+}
+
+// setStatusBit fetches the current "MODEM" status, applies mutate to it,
+// and writes the result back.
+func (t *Term) setStatusBit(mutate func(*Status)) error {
+	status, err := t.Status()
+	if err != nil {
+		return err
+	}
+	mutate(&status)
+	return t.SetStatus(status)
 }
@@ -0,0 +1,122 @@
+package term
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// errClosed is the sentinel wrapped in a *PortError when a Read is
+// unblocked by a concurrent Close rather than by a deadline.
+var errClosed = errors.New("port closed")
+
+// PortError is returned when waiting on a Term's port fails: a Read that
+// did not complete within its deadline, a Read or Write unblocked by
+// Close, or an error from the poll call that waits on the port.
+type PortError struct {
+	Op  string
+	Err error
+}
+
+func (e *PortError) Error() string { return "term: " + e.Op + ": " + e.Err.Error() }
+
+// Unwrap allows PortError to be matched with errors.Is/errors.As, e.g.
+// against os.ErrDeadlineExceeded.
+func (e *PortError) Unwrap() error { return e.Err }
+
+// Timeout reports whether the error represents a deadline that expired.
+func (e *PortError) Timeout() bool { return e.Err == os.ErrDeadlineExceeded }
+
+// SetReadTimeout sets a relative timeout applied to every future Read
+// call: if d elapses with no data available, Read returns a *PortError.
+// A zero d disables the timeout and lets Read block indefinitely, unless
+// a deadline set with SetDeadline is also in effect.
+func (t *Term) SetReadTimeout(d time.Duration) error {
+	t.timeoutMu.Lock()
+	defer t.timeoutMu.Unlock()
+	t.readTimeout = d
+	return nil
+}
+
+// SetDeadline sets an absolute time after which an in-flight or future
+// Read returns a *PortError. A zero Time disables the deadline.
+func (t *Term) SetDeadline(tm time.Time) error {
+	t.timeoutMu.Lock()
+	defer t.timeoutMu.Unlock()
+	t.deadline = tm
+	return nil
+}
+
+// effectiveDeadline returns the absolute deadline Read should honor, or
+// the zero Time if Read should block indefinitely. A read timeout is
+// measured from start, the time the wait began. Unlike the fd and
+// buffer state Read holds t.mu for, the deadline fields live behind their
+// own mutex so SetReadTimeout/SetDeadline can always take effect on a
+// Read that is already blocked in waitReadable.
+func (t *Term) effectiveDeadline(start time.Time) time.Time {
+	t.timeoutMu.Lock()
+	defer t.timeoutMu.Unlock()
+	if !t.deadline.IsZero() {
+		return t.deadline
+	}
+	if t.readTimeout > 0 {
+		return start.Add(t.readTimeout)
+	}
+	return time.Time{}
+}
+
+// pollSlice bounds how long a single poll() call waits when there is no
+// deadline (or a distant one), so waitReadable re-reads effectiveDeadline
+// periodically instead of blocking past a deadline set after it started
+// waiting.
+const pollSlice = 200 * time.Millisecond
+
+// waitReadable blocks until the port fd is readable, the deadline (if any)
+// expires, or Close wakes the self-pipe. It reports whether the fd is
+// readable.
+//
+// This uses unix.Poll rather than unix.Select/FdSet: FdSet is a fixed
+// FD_SETSIZE=1024 bitmap, so Select would silently corrupt memory or miss
+// events for any fd at or beyond that ceiling (easily reached in a
+// long-running process with many open files). Poll has no such limit.
+func (t *Term) waitReadable() (bool, error) {
+	fds := []unix.PollFd{
+		{Fd: int32(t.fd), Events: unix.POLLIN},
+		{Fd: int32(t.pipeR), Events: unix.POLLIN},
+	}
+	start := time.Now()
+	for {
+		timeoutMs := int(pollSlice / time.Millisecond)
+		if deadline := t.effectiveDeadline(start); !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return false, nil
+			}
+			if remaining < pollSlice {
+				timeoutMs = int(remaining / time.Millisecond)
+				if timeoutMs == 0 {
+					timeoutMs = 1
+				}
+			}
+		}
+
+		n, err := unix.Poll(fds, timeoutMs)
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			return false, &PortError{Op: "read", Err: err}
+		}
+		if n == 0 {
+			continue
+		}
+		if fds[1].Revents&unix.POLLIN != 0 {
+			return false, &PortError{Op: "read", Err: errClosed}
+		}
+		if fds[0].Revents&unix.POLLIN != 0 {
+			return true, nil
+		}
+	}
+}
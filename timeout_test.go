@@ -0,0 +1,75 @@
+package term
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// newBlockedTerm returns a Term backed by a pipe that never becomes
+// readable, so waitReadable blocks until its deadline or self-pipe fires.
+func newBlockedTerm(t *testing.T) *Term {
+	data := make([]int, 2)
+	if err := unix.Pipe2(data, unix.O_CLOEXEC|unix.O_NONBLOCK); err != nil {
+		t.Fatal(err)
+	}
+	self := make([]int, 2)
+	if err := unix.Pipe2(self, unix.O_CLOEXEC|unix.O_NONBLOCK); err != nil {
+		t.Fatal(err)
+	}
+	term := &Term{fd: data[0], pipeR: self[0], pipeW: self[1]}
+	t.Cleanup(func() {
+		unix.Close(data[0])
+		unix.Close(data[1])
+		unix.Close(self[0])
+		unix.Close(self[1])
+	})
+	return term
+}
+
+func TestWaitReadableHonorsDeadlineSetWhileBlocked(t *testing.T) {
+	term := newBlockedTerm(t)
+
+	type result struct {
+		ready bool
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		ready, err := term.waitReadable()
+		done <- result{ready, err}
+	}()
+
+	// Give waitReadable time to start blocking with no deadline in effect.
+	time.Sleep(20 * time.Millisecond)
+	term.SetDeadline(time.Now().Add(10 * time.Millisecond))
+
+	select {
+	case r := <-done:
+		if r.err != nil || r.ready {
+			t.Fatalf("waitReadable returned (%v, %v), want (false, nil) for an expired deadline", r.ready, r.err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("waitReadable did not observe a deadline set after it started blocking")
+	}
+}
+
+func TestEffectiveDeadlineIndependentOfMu(t *testing.T) {
+	term := newBlockedTerm(t)
+
+	term.mu.Lock()
+	defer term.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		term.SetReadTimeout(time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("SetReadTimeout blocked on t.mu, which Read can hold for its whole duration")
+	}
+}